@@ -0,0 +1,157 @@
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/anchore/grype/grype/pkg/format"
+	"github.com/anchore/syft/syft/distro"
+)
+
+// init registers the CycloneDX JSON decoder under the spec versions this decoder understands.
+func init() {
+	format.RegisterDecoder("cyclonedx-json", []string{"1.2", "1.3", "1.4"}, decodeCycloneDXJSONDoc)
+}
+
+// decodeCycloneDXJSONDoc decodes a CycloneDX JSON document into a cyclonedxDocument.
+func decodeCycloneDXJSONDoc(r io.Reader) (interface{}, error) {
+	var doc cyclonedxDocument
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// cyclonedxJSONProvider extracts the necessary package and package context from a CycloneDX JSON document, such
+// as the BOMs produced by paketo buildpacks, trivy, or cdxgen.
+func cyclonedxJSONProvider(config providerConfig) ([]Package, Context, error) {
+	reader, err := getSyftJSON(config) // CycloneDX documents arrive through the same SBOM input plumbing as syft JSON
+	if err != nil {
+		return nil, Context{}, err
+	}
+	defer closeReader(reader)
+
+	return parseCycloneDXJSON(reader)
+}
+
+// cyclonedxDocument is the subset of a CycloneDX JSON BOM needed to build packages + context.
+type cyclonedxDocument struct {
+	BOMFormat  string               `json:"bomFormat"`
+	Components []cyclonedxComponent `json:"components"`
+}
+
+// cyclonedxComponent is the subset of a CycloneDX component element needed to build a Package.
+type cyclonedxComponent struct {
+	Type               string                       `json:"type"`
+	Name               string                       `json:"name"`
+	Version            string                       `json:"version"`
+	PURL               string                       `json:"purl"`
+	CPE                string                       `json:"cpe"`
+	Licenses           []cyclonedxLicenseChoice     `json:"licenses"`
+	ExternalReferences []cyclonedxExternalReference `json:"externalReferences"`
+}
+
+// cyclonedxLicenseChoice mirrors CycloneDX's licenses[].license object, which may carry either a license SPDX
+// id or a free-form name.
+type cyclonedxLicenseChoice struct {
+	License struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"license"`
+}
+
+// cyclonedxExternalReference is a CycloneDX externalReferences[] entry; "advisories" and "vcs" references are
+// inspected for additional CPE inference.
+type cyclonedxExternalReference struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// parseCycloneDXJSON detects the CycloneDX spec version of the given document and decodes it with the matching
+// registered decoder before converting its components into grype's Package/Context shape.
+func parseCycloneDXJSON(reader io.Reader) ([]Package, Context, error) {
+	id, version, buffered, err := format.Detect(reader)
+	if err != nil || id != "cyclonedx-json" {
+		return nil, Context{}, errDoesNotProvide
+	}
+
+	decoded, err := format.Decode(id, version, buffered)
+	if err != nil {
+		return nil, Context{}, fmt.Errorf("detected CycloneDX schema version %q but cannot read it: %w", version, err)
+	}
+
+	doc, ok := decoded.(*cyclonedxDocument)
+	if !ok {
+		return nil, Context{}, fmt.Errorf("cyclonedx-json decoder returned unexpected type %T", decoded)
+	}
+
+	var packages = make([]Package, 0, len(doc.Components))
+	var theDistro *distro.Distro
+
+	for i, c := range doc.Components {
+		var candidates []string
+		if c.CPE != "" {
+			candidates = append(candidates, c.CPE)
+		}
+		candidates = append(candidates, cyclonedxInferredCPEs(c.ExternalReferences)...)
+
+		p := Package{
+			id:       ID(i),
+			Name:     c.Name,
+			Version:  c.Version,
+			Licenses: cyclonedxLicenses(c.Licenses),
+			Type:     purlToPackageType(c.PURL),
+			CPEs:     validCPEs(candidates),
+			PURL:     c.PURL,
+		}
+
+		if c.Type == "operating-system" && theDistro == nil {
+			if d, err := distro.NewDistro(distro.Type(strings.ToLower(c.Name)), c.Version, ""); err == nil {
+				theDistro = &d
+			}
+		}
+
+		packages = append(packages, p)
+	}
+
+	return packages, Context{
+		Distro: theDistro,
+	}, nil
+}
+
+// cyclonedxLicenses flattens CycloneDX's licenses[].license.id/name choices into grype's flat license list.
+func cyclonedxLicenses(choices []cyclonedxLicenseChoice) []string {
+	var licenses []string
+	for _, choice := range choices {
+		switch {
+		case choice.License.ID != "":
+			licenses = append(licenses, choice.License.ID)
+		case choice.License.Name != "":
+			licenses = append(licenses, choice.License.Name)
+		}
+	}
+	return licenses
+}
+
+// cyclonedxEmbeddedCPEPattern matches a CPE 2.3 or CPE 2.2 (URI-bound) identifier embedded anywhere within a
+// larger string, such as a CPE string appended to an NVD advisory URL rather than standing alone.
+var cyclonedxEmbeddedCPEPattern = regexp.MustCompile(`cpe:(?:2\.3:[^\s&"']+|/[^\s&"']+)`)
+
+// cyclonedxInferredCPEs extracts additional CPE hints from "advisories" and "vcs" externalReferences, which
+// some SBOM generators use to carry vulnerability-database identifiers outside of the dedicated cpe field. Only
+// the matched CPE substring is returned, not the full reference URL it was found in.
+func cyclonedxInferredCPEs(refs []cyclonedxExternalReference) []string {
+	var cpes []string
+	for _, ref := range refs {
+		if ref.Type != "advisories" && ref.Type != "vcs" {
+			continue
+		}
+		if match := cyclonedxEmbeddedCPEPattern.FindString(ref.URL); match != "" {
+			cpes = append(cpes, match)
+		}
+	}
+	return cpes
+}
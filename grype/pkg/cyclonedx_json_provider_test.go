@@ -0,0 +1,91 @@
+package pkg
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+const cyclonedxJSONFixture = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.4",
+  "components": [
+    {
+      "type": "library",
+      "name": "openssl",
+      "version": "1.1.1f",
+      "purl": "pkg:deb/openssl@1.1.1f",
+      "licenses": [{"license": {"id": "Apache-2.0"}}, {"license": {"name": "Custom License"}}],
+      "externalReferences": [
+        {"type": "advisories", "url": "https://nvd.nist.gov/vuln/detail?cpe=cpe:2.3:a:openssl:openssl:1.1.1f:*:*:*:*:*:*:*&cveId=CVE-2020-1971"}
+      ]
+    },
+    {
+      "type": "operating-system",
+      "name": "ubuntu",
+      "version": "20.04"
+    },
+    {
+      "type": "library",
+      "name": "lodash",
+      "version": "4.17.15",
+      "purl": "pkg:npm/lodash@4.17.15"
+    }
+  ]
+}`
+
+func TestParseCycloneDXJSON(t *testing.T) {
+	packages, ctx, err := parseCycloneDXJSON(strings.NewReader(cyclonedxJSONFixture))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(packages) != 3 {
+		t.Fatalf("expected 3 packages, got %d", len(packages))
+	}
+
+	openssl := packages[0]
+	if openssl.Name != "openssl" || openssl.Version != "1.1.1f" {
+		t.Errorf("unexpected openssl package: %+v", openssl)
+	}
+	if len(openssl.Licenses) != 2 || openssl.Licenses[0] != "Apache-2.0" || openssl.Licenses[1] != "Custom License" {
+		t.Errorf("unexpected licenses: %v", openssl.Licenses)
+	}
+	if len(openssl.CPEs) != 1 {
+		t.Fatalf("expected the embedded CPE to be extracted from the advisory URL, got %d CPEs", len(openssl.CPEs))
+	}
+	if openssl.Type != pkg.DebPkg {
+		t.Errorf("expected openssl's type to be derived from its deb purl, got %q", openssl.Type)
+	}
+
+	lodash := packages[2]
+	if lodash.Type != pkg.NpmPkg {
+		t.Errorf("expected lodash's type to be derived from its npm purl, not left empty, got %q", lodash.Type)
+	}
+
+	if ctx.Distro == nil {
+		t.Fatal("expected a distro to be synthesized from the operating-system component")
+	}
+	if string(ctx.Distro.Type) != "ubuntu" {
+		t.Errorf("expected distro type 'ubuntu', got %q", ctx.Distro.Type)
+	}
+}
+
+func TestCyclonedxInferredCPEsSkipsUnparsableURLs(t *testing.T) {
+	refs := []cyclonedxExternalReference{
+		{Type: "advisories", URL: "https://example.com/advisory/does-not-mention-a-cpe"},
+		{Type: "advisories", URL: "https://nvd.nist.gov/vuln/detail?cpe=cpe:2.3:a:curl:curl:7.68.0:*:*:*:*:*:*:*"},
+		{Type: "vcs", URL: "https://github.com/curl/curl"},
+	}
+
+	candidates := cyclonedxInferredCPEs(refs)
+	if len(candidates) != 1 {
+		t.Fatalf("expected exactly 1 inferred CPE candidate, got %d: %v", len(candidates), candidates)
+	}
+
+	valid := validCPEs(append(candidates, "not-a-cpe-at-all"))
+	if len(valid) != 1 {
+		t.Errorf("expected the unparsable candidate to be skipped, not abort the whole document, got %d", len(valid))
+	}
+}
@@ -0,0 +1,71 @@
+package format
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// peekSize is the number of bytes read from the front of the stream to make a format + schema version
+// determination. This is generous enough to reach past whitespace and any leading array/object wrapper to the
+// first few top-level keys of real-world SBOM documents.
+const peekSize = 8192
+
+var (
+	schemaVersionPattern  = regexp.MustCompile(`"schemaVersion"\s*:\s*"?([0-9]+(?:\.[0-9]+)*)"?`)
+	spdxVersionPattern    = regexp.MustCompile(`"spdxVersion"\s*:\s*"SPDX-([0-9]+\.[0-9]+)"`)
+	specVersionPattern    = regexp.MustCompile(`"specVersion"\s*:\s*"([0-9]+(?:\.[0-9]+)*)"`)
+	tagValueSPDXVersionRe = regexp.MustCompile(`SPDXVersion:\s*SPDX-([0-9]+\.[0-9]+)`)
+)
+
+// Detect peeks at the front of r to determine which registered format (and schema version within that format)
+// the stream holds, without consuming it: the returned buffered reader replays the peeked bytes followed by the
+// remainder of r, so callers can Decode directly from it.
+func Detect(r io.Reader) (id string, version string, buffered io.Reader, err error) {
+	br := bufio.NewReaderSize(r, peekSize)
+
+	peeked, _ := br.Peek(peekSize)
+	head := string(peeked)
+	trimmed := strings.TrimSpace(head)
+
+	switch {
+	case strings.Contains(head, `"spdxVersion"`):
+		return "spdx-json", firstMatch(spdxVersionPattern, head, "2.2"), br, nil
+
+	case strings.HasPrefix(trimmed, "SPDXVersion:"):
+		return "spdx-tag-value", firstMatch(tagValueSPDXVersionRe, head, "2.2"), br, nil
+
+	case strings.Contains(head, `"bomFormat"`) && strings.Contains(head, "CycloneDX"):
+		return "cyclonedx-json", firstMatch(specVersionPattern, head, "1.4"), br, nil
+
+	case strings.Contains(head, `"artifacts"`) || strings.Contains(head, `"descriptor"`):
+		return "syft-json", syftSchemaVersion(head), br, nil
+
+	default:
+		return "", "", br, fmt.Errorf("unable to detect SBOM format from input")
+	}
+}
+
+// syftSchemaVersion extracts the major version of a syft JSON document's schema field, defaulting to the oldest
+// supported series ("1") when the field is absent (as in pre-schema-versioning syft JSON documents).
+func syftSchemaVersion(head string) string {
+	match := schemaVersionPattern.FindStringSubmatch(head)
+	if len(match) < 2 {
+		return "1"
+	}
+	major := match[1]
+	if idx := strings.Index(major, "."); idx >= 0 {
+		major = major[:idx]
+	}
+	return major
+}
+
+func firstMatch(re *regexp.Regexp, s, fallback string) string {
+	match := re.FindStringSubmatch(s)
+	if len(match) < 2 {
+		return fallback
+	}
+	return match[1]
+}
@@ -0,0 +1,39 @@
+/*
+Package format provides a registry of SBOM decoders keyed by format ID and schema version. Providers detect the
+format of an input stream with Detect, then look up (and invoke) the matching decoder with Decode, rather than
+each provider carrying its own ad-hoc, best-effort unmarshalling of a single evolving document shape.
+*/
+package format
+
+import (
+	"fmt"
+	"io"
+)
+
+// DecoderFn decodes a reader of a known format + schema version into a format-specific document value. The
+// returned value is left as an interface{}; callers type-assert based on the id they requested.
+type DecoderFn func(r io.Reader) (interface{}, error)
+
+type decoderKey struct {
+	id      string
+	version string
+}
+
+var decoders = map[decoderKey]DecoderFn{}
+
+// RegisterDecoder registers fn as the decoder for the given format id across the given list of schema versions.
+// Providers call this from an init() function so the registry is populated before Detect/Decode are used.
+func RegisterDecoder(id string, versions []string, fn DecoderFn) {
+	for _, version := range versions {
+		decoders[decoderKey{id: id, version: version}] = fn
+	}
+}
+
+// Decode looks up the decoder registered for the given (id, version) pair and invokes it against r.
+func Decode(id, version string, r io.Reader) (interface{}, error) {
+	fn, exists := decoders[decoderKey{id: id, version: version}]
+	if !exists {
+		return nil, fmt.Errorf("unsupported schema version %q for format %q: no decoder registered", version, id)
+	}
+	return fn(r)
+}
@@ -0,0 +1,106 @@
+package format
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expectID    string
+		expectVer   string
+		expectError bool
+	}{
+		{
+			name:      "spdx json",
+			input:     `{"spdxVersion": "SPDX-2.3", "packages": []}`,
+			expectID:  "spdx-json",
+			expectVer: "2.3",
+		},
+		{
+			name:      "spdx json defaults version when unrecognized",
+			input:     `{"packages": [], "spdxVersion": "not-a-match"}`,
+			expectID:  "spdx-json",
+			expectVer: "2.2",
+		},
+		{
+			name:      "spdx tag-value",
+			input:     "SPDXVersion: SPDX-2.2\nDataLicense: CC0-1.0\n",
+			expectID:  "spdx-tag-value",
+			expectVer: "2.2",
+		},
+		{
+			name:      "cyclonedx json",
+			input:     `{"bomFormat": "CycloneDX", "specVersion": "1.4", "components": []}`,
+			expectID:  "cyclonedx-json",
+			expectVer: "1.4",
+		},
+		{
+			name:      "syft json",
+			input:     `{"artifacts": [], "schemaVersion": "3.1.0"}`,
+			expectID:  "syft-json",
+			expectVer: "3",
+		},
+		{
+			name:        "unrecognized input",
+			input:       `{"foo": "bar"}`,
+			expectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			id, version, buffered, err := Detect(strings.NewReader(test.input))
+			if test.expectError {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if id != test.expectID {
+				t.Errorf("expected id %q, got %q", test.expectID, id)
+			}
+			if version != test.expectVer {
+				t.Errorf("expected version %q, got %q", test.expectVer, version)
+			}
+
+			replayed, err := io.ReadAll(buffered)
+			if err != nil {
+				t.Fatalf("unexpected error reading buffered reader: %v", err)
+			}
+			if string(replayed) != test.input {
+				t.Errorf("buffered reader did not replay the full input: got %q", replayed)
+			}
+		})
+	}
+}
+
+func TestRegisterDecoderAndDecode(t *testing.T) {
+	const testFormatID = "test-format"
+
+	RegisterDecoder(testFormatID, []string{"1.0"}, func(r io.Reader) (interface{}, error) {
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	})
+
+	decoded, err := Decode(testFormatID, "1.0", strings.NewReader("hello"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded != "hello" {
+		t.Errorf("expected decoded value %q, got %v", "hello", decoded)
+	}
+
+	if _, err := Decode(testFormatID, "9.9", strings.NewReader("hello")); err == nil {
+		t.Error("expected an error decoding an unregistered schema version")
+	}
+}
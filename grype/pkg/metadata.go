@@ -0,0 +1,28 @@
+package pkg
+
+// RpmdbMetadata represents all metadata parsed from the rpm database (rpmdb) that is relevant to vulnerability
+// matching.
+type RpmdbMetadata struct {
+	Epoch   *int   `mapstructure:"Epoch" json:"epoch"`
+	Name    string `mapstructure:"Name" json:"name"`
+	Version string `mapstructure:"Version" json:"version"`
+	Arch    string `mapstructure:"Arch" json:"arch"`
+}
+
+// DpkgMetadata represents all captured data for a Debian package entry.
+type DpkgMetadata struct {
+	Package string `mapstructure:"Package" json:"package"`
+	Source  string `mapstructure:"Source" json:"source"`
+	Version string `mapstructure:"Version" json:"version"`
+	Arch    string `mapstructure:"Arch" json:"architecture"`
+}
+
+// JavaMetadata encapsulates all Java ecosystem metadata captured about a package.
+type JavaMetadata struct {
+	PomArtifactID string `mapstructure:"PomArtifactID" json:"pomArtifactID"`
+	PomGroupID    string `mapstructure:"PomGroupID" json:"pomGroupID"`
+	ManifestName  string `mapstructure:"ManifestName" json:"manifestName"`
+	// ArchiveDigests are the content digests syft records for the archive itself, letting matchers disambiguate
+	// a repackaged artifact from its known-vulnerable upstream by digest rather than name/version alone.
+	ArchiveDigests []Digest `mapstructure:"ArchiveDigests" json:"archiveDigests"`
+}
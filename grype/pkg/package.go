@@ -0,0 +1,40 @@
+package pkg
+
+import (
+	"github.com/anchore/grype/grype/cpe"
+	"github.com/anchore/syft/syft/distro"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+// ID references a package uniquely relative to the set of packages returned by a single provider invocation.
+type ID int
+
+// Package represents an application or library that has been detected from SBOM ingestion or source analysis,
+// carrying only the data grype's matchers need (as opposed to syft's full package model).
+type Package struct {
+	id        ID
+	Name      string
+	Version   string
+	Locations []source.Location
+	Licenses  []string
+	Language  pkg.Language
+	Type      pkg.Type
+	CPEs      []cpe.CPE
+	PURL      string
+	Metadata  interface{}
+	// Files carries per-file path and content digests for files the package is known to contain, letting
+	// matchers (notably Java) disambiguate a repackaged artifact from its upstream by digest.
+	Files []FileMetadata
+}
+
+// ID returns the package's identifier, unique relative to the set of packages it was parsed alongside.
+func (p Package) ID() ID {
+	return p.id
+}
+
+// Context provides supplemental information about the environment the packages were discovered in.
+type Context struct {
+	Source *source.Metadata
+	Distro *distro.Distro
+}
@@ -0,0 +1,107 @@
+package pkg
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/anchore/grype/grype/cpe"
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// providerConfig carries the input needed by a provider to locate and read an SBOM or raw scan target.
+type providerConfig struct {
+	userInput string
+	reader    io.Reader
+}
+
+// errDoesNotProvide is returned by a provider when the given input isn't one it's able to handle, so Provide
+// falls through to the next provider in the list instead of treating it as a hard failure.
+var errDoesNotProvide = fmt.Errorf("unable to determine packages from the given input")
+
+// providers is the ordered list of provider functions Provide tries, in turn, against a given input.
+var providers = []func(providerConfig) ([]Package, Context, error){
+	syftJSONProvider,
+	spdxJSONProvider,
+	spdxTagValueProvider,
+	cyclonedxJSONProvider,
+}
+
+// Provide runs each registered provider against the given user input in order, returning the first one that
+// successfully produces packages. Providers that can't handle the input return errDoesNotProvide so later
+// providers in the list get a chance to.
+func Provide(userInput string) ([]Package, Context, error) {
+	config := providerConfig{userInput: userInput}
+
+	for _, provider := range providers {
+		packages, ctx, err := provider(config)
+		switch {
+		case err == errDoesNotProvide:
+			continue
+		case err != nil:
+			return nil, Context{}, err
+		default:
+			return packages, ctx, nil
+		}
+	}
+
+	return nil, Context{}, fmt.Errorf("unable to determine packages for input %q", userInput)
+}
+
+// validCPEs parses each candidate CPE independently, dropping any that fail to parse rather than letting one
+// malformed or unsupported CPE abort conversion of the whole document. Shared by every provider that infers CPEs
+// from format-native fields that aren't guaranteed to already be well-formed CPEs (SPDX external refs, CycloneDX
+// external references, syft's own "cpes" field).
+func validCPEs(candidates []string) []cpe.CPE {
+	var valid []cpe.CPE
+	for _, candidate := range candidates {
+		parsed, err := cpe.NewSlice(candidate)
+		if err != nil {
+			continue
+		}
+		valid = append(valid, parsed...)
+	}
+	return valid
+}
+
+// closeReader closes r if it's backed by something closeable (e.g. the *os.File getSyftJSON opens for a
+// filesystem path), so that a provider which turns out not to apply to the given input doesn't leak the handle
+// it opened while checking.
+func closeReader(r io.Reader) {
+	if closer, ok := r.(io.Closer); ok {
+		closer.Close()
+	}
+}
+
+// purlToPackageType maps a package URL's type segment (e.g. "pkg:npm/...") onto grype's pkg.Type. Both the SPDX
+// and CycloneDX providers derive a package's type this way rather than from a format-native field, since neither
+// has one that maps directly onto a pkg.Type: SPDX has no type field at all, and CycloneDX's component "type" is
+// too coarse (e.g. "library"/"application" rather than an ecosystem).
+func purlToPackageType(purl string) pkg.Type {
+	switch {
+	case strings.HasPrefix(purl, "pkg:rpm/"):
+		return pkg.RpmPkg
+	case strings.HasPrefix(purl, "pkg:deb/"):
+		return pkg.DebPkg
+	case strings.HasPrefix(purl, "pkg:apk/"):
+		return pkg.ApkPkg
+	case strings.HasPrefix(purl, "pkg:npm/"):
+		return pkg.NpmPkg
+	case strings.HasPrefix(purl, "pkg:pypi/"):
+		return pkg.PythonPkg
+	case strings.HasPrefix(purl, "pkg:gem/"):
+		return pkg.GemPkg
+	case strings.HasPrefix(purl, "pkg:maven/"):
+		return pkg.JavaPkg
+	case strings.HasPrefix(purl, "pkg:golang/"):
+		return pkg.GoModulePkg
+	case strings.HasPrefix(purl, "pkg:cargo/"):
+		return pkg.RustPkg
+	case strings.HasPrefix(purl, "pkg:nuget/"):
+		return pkg.DotnetPkg
+	case strings.HasPrefix(purl, "pkg:composer/"):
+		return pkg.PhpComposerPkg
+	default:
+		return ""
+	}
+}
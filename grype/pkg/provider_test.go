@@ -0,0 +1,72 @@
+package pkg
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+type closeTrackingReader struct {
+	*strings.Reader
+	closed bool
+}
+
+func (c *closeTrackingReader) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestCloseReaderClosesACloser(t *testing.T) {
+	r := &closeTrackingReader{Reader: strings.NewReader("")}
+	closeReader(r)
+	if !r.closed {
+		t.Error("expected closeReader to close a reader that implements io.Closer")
+	}
+}
+
+func TestCloseReaderIgnoresANonCloser(t *testing.T) {
+	// strings.Reader doesn't implement io.Closer; closeReader must not panic on it.
+	closeReader(strings.NewReader("hello"))
+}
+
+func TestProviderClosesReaderEvenWhenItDoesNotApply(t *testing.T) {
+	// spdxJSONProvider doesn't claim a CycloneDX document, but it still opens (here, is handed) a reader while
+	// checking - that reader must be closed rather than leaked when the provider gives up on it.
+	r := &closeTrackingReader{Reader: strings.NewReader(cyclonedxJSONFixture)}
+
+	_, _, err := spdxJSONProvider(providerConfig{reader: r})
+	if err != errDoesNotProvide {
+		t.Fatalf("expected errDoesNotProvide for a CycloneDX document, got %v", err)
+	}
+	if !r.closed {
+		t.Error("expected the reader to be closed once the provider determined it doesn't apply")
+	}
+}
+
+func TestPurlToPackageType(t *testing.T) {
+	tests := []struct {
+		purl     string
+		expected pkg.Type
+	}{
+		{"pkg:rpm/centos/bash@4.2.46", pkg.RpmPkg},
+		{"pkg:deb/debian/curl@7.68.0", pkg.DebPkg},
+		{"pkg:apk/alpine/busybox@1.31.1", pkg.ApkPkg},
+		{"pkg:npm/lodash@4.17.15", pkg.NpmPkg},
+		{"pkg:pypi/requests@2.25.1", pkg.PythonPkg},
+		{"pkg:gem/rails@6.1.0", pkg.GemPkg},
+		{"pkg:maven/org.apache.commons/commons-lang3@3.12.0", pkg.JavaPkg},
+		{"pkg:golang/github.com/anchore/grype@v0.1.0", pkg.GoModulePkg},
+		{"pkg:cargo/rand@0.8.3", pkg.RustPkg},
+		{"pkg:nuget/Newtonsoft.Json@12.0.3", pkg.DotnetPkg},
+		{"pkg:composer/symfony/console@5.2.3", pkg.PhpComposerPkg},
+		{"", pkg.Type("")},
+		{"not-a-purl", pkg.Type("")},
+	}
+
+	for _, test := range tests {
+		if got := purlToPackageType(test.purl); got != test.expected {
+			t.Errorf("purlToPackageType(%q) = %q, want %q", test.purl, got, test.expected)
+		}
+	}
+}
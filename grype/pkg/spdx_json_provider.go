@@ -0,0 +1,226 @@
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/anchore/grype/grype/pkg/format"
+	"github.com/anchore/syft/syft/distro"
+)
+
+// init registers the SPDX JSON decoder under the 2.2 and 2.3 schema series, the versions this decoder understands.
+func init() {
+	format.RegisterDecoder("spdx-json", []string{"2.2", "2.3"}, decodeSPDXJSONDoc)
+}
+
+// decodeSPDXJSONDoc decodes an SPDX JSON document into an spdxDocument.
+func decodeSPDXJSONDoc(r io.Reader) (interface{}, error) {
+	var doc spdxDocument
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// spdxJSONProvider extracts the necessary package and package context from an SPDX JSON document. Only the subset
+// of the SPDX 2.2/2.3 shape needed to populate a Package / Context is unmarshalled; everything else is ignored.
+func spdxJSONProvider(config providerConfig) ([]Package, Context, error) {
+	reader, err := getSyftJSON(config) // SPDX documents arrive through the same SBOM input plumbing as syft JSON
+	if err != nil {
+		return nil, Context{}, err
+	}
+	defer closeReader(reader)
+
+	return parseSPDXJSON(reader)
+}
+
+// spdxDocument is the subset of an SPDX 2.2/2.3 JSON document needed to build packages + context.
+type spdxDocument struct {
+	SPDXVersion string        `json:"spdxVersion"`
+	Packages    []spdxPackage `json:"packages"`
+}
+
+// spdxPackage is the subset of an SPDX package element needed to build a Package.
+type spdxPackage struct {
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo"`
+	Supplier         string            `json:"supplier"`
+	Originator       string            `json:"originator"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs"`
+}
+
+// spdxExternalRef captures the PACKAGE-MANAGER (PURL) and SECURITY (CPE) external references SPDX uses to
+// cross-reference a package against other ecosystems.
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+// parseSPDXJSON detects the SPDX schema version of the given document and decodes it with the matching registered
+// decoder before converting its packages into grype's Package/Context shape.
+func parseSPDXJSON(reader io.Reader) ([]Package, Context, error) {
+	id, version, buffered, err := format.Detect(reader)
+	if err != nil || id != "spdx-json" {
+		return nil, Context{}, errDoesNotProvide
+	}
+
+	decoded, err := format.Decode(id, version, buffered)
+	if err != nil {
+		return nil, Context{}, fmt.Errorf("detected SPDX JSON schema version %q but cannot read it: %w", version, err)
+	}
+
+	doc, ok := decoded.(*spdxDocument)
+	if !ok {
+		return nil, Context{}, fmt.Errorf("spdx-json decoder returned unexpected type %T", decoded)
+	}
+
+	return spdxPackagesToCatalog(doc.Packages)
+}
+
+// spdxPackagesToCatalog converts the SPDX-native package shape into grype's Package/Context shape, shared by
+// both the JSON and tag-value SPDX providers.
+func spdxPackagesToCatalog(spdxPkgs []spdxPackage) ([]Package, Context, error) {
+	var packages = make([]Package, 0, len(spdxPkgs))
+	var theDistro *distro.Distro
+
+	for i, p := range spdxPkgs {
+		purl, cpes := spdxExternalRefsToPURLAndCPEs(p.ExternalRefs)
+
+		licenses := spdxPackageLicenses(p)
+
+		if theDistro == nil {
+			if d := spdxDistroFromPackage(p, purl); d != nil {
+				theDistro = d
+			}
+		}
+
+		packages = append(packages, Package{
+			id:       ID(i),
+			Name:     p.Name,
+			Version:  p.VersionInfo,
+			Licenses: licenses,
+			Type:     purlToPackageType(purl),
+			CPEs:     validCPEs(cpes),
+			PURL:     purl,
+		})
+	}
+
+	return packages, Context{
+		Distro: theDistro,
+	}, nil
+}
+
+// spdxExternalRefsToPURLAndCPEs pulls the PURL (from a PACKAGE-MANAGER reference) and any CPEs (from SECURITY
+// references) out of an SPDX package's externalRefs.
+func spdxExternalRefsToPURLAndCPEs(refs []spdxExternalRef) (string, []string) {
+	var purl string
+	var cpes []string
+
+	for _, ref := range refs {
+		switch ref.ReferenceCategory {
+		case "PACKAGE-MANAGER":
+			if ref.ReferenceType == "purl" {
+				purl = ref.ReferenceLocator
+			}
+		case "SECURITY":
+			if ref.ReferenceType == "cpe23Type" || ref.ReferenceType == "cpe22Type" {
+				cpes = append(cpes, ref.ReferenceLocator)
+			}
+		}
+	}
+
+	return purl, cpes
+}
+
+// spdxPackageLicenses collapses the concluded/declared license fields into the flat license list grype expects,
+// ignoring SPDX's NOASSERTION/NONE sentinels.
+func spdxPackageLicenses(p spdxPackage) []string {
+	var licenses []string
+	for _, l := range []string{p.LicenseConcluded, p.LicenseDeclared} {
+		if l == "" || l == "NOASSERTION" || l == "NONE" {
+			continue
+		}
+		licenses = append(licenses, l)
+	}
+	return licenses
+}
+
+// spdxDistroFromPackage synthesizes a distro.Distro when an SPDX package appears to describe the Linux
+// distribution itself, inferred from the supplier/originator organization fields together with an rpm/deb PURL.
+func spdxDistroFromPackage(p spdxPackage, purl string) *distro.Distro {
+	org := spdxOrganization(p.Supplier)
+	if org == "" {
+		org = spdxOrganization(p.Originator)
+	}
+
+	if org == "" || (!strings.HasPrefix(purl, "pkg:rpm/") && !strings.HasPrefix(purl, "pkg:deb/")) {
+		return nil
+	}
+
+	distroType, ok := spdxOrganizationToDistroType(org)
+	if !ok {
+		return nil
+	}
+
+	d, err := distro.NewDistro(distroType, p.VersionInfo, "")
+	if err != nil {
+		return nil
+	}
+	return &d
+}
+
+// spdxOrganization extracts the organization name from an SPDX supplier/originator field of the form
+// "Organization: Red Hat".
+func spdxOrganization(field string) string {
+	const prefix = "Organization: "
+	if !strings.HasPrefix(field, prefix) {
+		return ""
+	}
+	return strings.TrimPrefix(field, prefix)
+}
+
+// spdxOrgLegalSuffixes are the legal-entity suffixes stripped from a supplier/originator organization name before
+// it's matched against spdxKnownDistroOrgs, since real-world values are free text like "Red Hat, Inc." rather
+// than a bare distro name.
+var spdxOrgLegalSuffixes = []string{" inc", " incorporated", " corp", " corporation", " llc", " ltd", " gmbh", " ag"}
+
+// spdxKnownDistroOrgs maps a recognizable fragment of a normalized organization name to the short distro.Type
+// token distro.NewDistro expects (e.g. "redhat", not "Red Hat, Inc.").
+var spdxKnownDistroOrgs = map[string]distro.Type{
+	"red hat":   "redhat",
+	"redhat":    "redhat",
+	"debian":    "debian",
+	"canonical": "ubuntu",
+	"ubuntu":    "ubuntu",
+	"suse":      "sles",
+	"alpine":    "alpine",
+	"centos":    "centos",
+	"fedora":    "fedora",
+	"amazon":    "amazonlinux",
+}
+
+// spdxOrganizationToDistroType normalizes a free-form SPDX organization string (punctuation and legal suffixes
+// removed) and looks it up against the known distro-supplier organizations, since passing the raw organization
+// string to distro.NewDistro would never match a real distro.Type token.
+func spdxOrganizationToDistroType(org string) (distro.Type, bool) {
+	normalized := strings.ToLower(org)
+	normalized = strings.NewReplacer(",", "", ".", "").Replace(normalized)
+	normalized = strings.TrimSpace(normalized)
+
+	for _, suffix := range spdxOrgLegalSuffixes {
+		normalized = strings.TrimSuffix(normalized, suffix)
+	}
+	normalized = strings.TrimSpace(normalized)
+
+	for fragment, distroType := range spdxKnownDistroOrgs {
+		if strings.Contains(normalized, fragment) {
+			return distroType, true
+		}
+	}
+	return "", false
+}
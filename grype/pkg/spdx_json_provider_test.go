@@ -0,0 +1,121 @@
+package pkg
+
+import (
+	"strings"
+	"testing"
+)
+
+const spdxJSONFixture = `{
+  "spdxVersion": "SPDX-2.2",
+  "packages": [
+    {
+      "name": "curl",
+      "versionInfo": "7.68.0-1ubuntu2",
+      "licenseConcluded": "NOASSERTION",
+      "licenseDeclared": "MIT",
+      "externalRefs": [
+        {"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:deb/curl@7.68.0-1ubuntu2"},
+        {"referenceCategory": "SECURITY", "referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:a:curl:curl:7.68.0:*:*:*:*:*:*:*"}
+      ]
+    },
+    {
+      "name": "ubuntu",
+      "versionInfo": "20.04",
+      "supplier": "Organization: Canonical, Inc.",
+      "externalRefs": [
+        {"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:deb/ubuntu@20.04"}
+      ]
+    }
+  ]
+}`
+
+func TestParseSPDXJSON(t *testing.T) {
+	packages, ctx, err := parseSPDXJSON(strings.NewReader(spdxJSONFixture))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(packages) != 2 {
+		t.Fatalf("expected 2 packages, got %d", len(packages))
+	}
+
+	curl := packages[0]
+	if curl.Name != "curl" || curl.Version != "7.68.0-1ubuntu2" {
+		t.Errorf("unexpected curl package: %+v", curl)
+	}
+	if curl.PURL != "pkg:deb/curl@7.68.0-1ubuntu2" {
+		t.Errorf("unexpected PURL: %s", curl.PURL)
+	}
+	if len(curl.CPEs) != 1 {
+		t.Errorf("expected 1 CPE, got %d", len(curl.CPEs))
+	}
+	if len(curl.Licenses) != 1 || curl.Licenses[0] != "MIT" {
+		t.Errorf("expected NOASSERTION to be dropped and MIT kept, got %v", curl.Licenses)
+	}
+
+	if ctx.Distro == nil {
+		t.Fatal("expected a distro to be synthesized from the Canonical package")
+	}
+	if string(ctx.Distro.Type) != "ubuntu" {
+		t.Errorf("expected distro type 'ubuntu', got %q", ctx.Distro.Type)
+	}
+}
+
+func TestSpdxPackagesToCatalogSkipsBadCPELocator(t *testing.T) {
+	spdxPkgs := []spdxPackage{
+		{
+			Name:        "curl",
+			VersionInfo: "7.68.0-1ubuntu2",
+			ExternalRefs: []spdxExternalRef{
+				{ReferenceCategory: "SECURITY", ReferenceType: "cpe23Type", ReferenceLocator: "not-a-valid-cpe"},
+			},
+		},
+		{
+			Name:        "openssl",
+			VersionInfo: "1.1.1f",
+			ExternalRefs: []spdxExternalRef{
+				{ReferenceCategory: "SECURITY", ReferenceType: "cpe23Type", ReferenceLocator: "cpe:2.3:a:openssl:openssl:1.1.1f:*:*:*:*:*:*:*"},
+			},
+		},
+	}
+
+	packages, _, err := spdxPackagesToCatalog(spdxPkgs)
+	if err != nil {
+		t.Fatalf("a malformed CPE locator on one package should not abort conversion of the rest of the document: %v", err)
+	}
+	if len(packages) != 2 {
+		t.Fatalf("expected both packages to still be present, got %d", len(packages))
+	}
+
+	if len(packages[0].CPEs) != 0 {
+		t.Errorf("expected the unparsable CPE to be dropped, got %v", packages[0].CPEs)
+	}
+	if len(packages[1].CPEs) != 1 {
+		t.Errorf("expected the valid CPE on the second package to still parse, got %v", packages[1].CPEs)
+	}
+}
+
+func TestSpdxOrganizationToDistroType(t *testing.T) {
+	tests := []struct {
+		org      string
+		expected string
+		ok       bool
+	}{
+		{"Organization: Red Hat, Inc.", "redhat", true},
+		{"Canonical, Inc.", "ubuntu", true},
+		{"SUSE LLC", "sles", true},
+		{"Some Unrelated Vendor", "", false},
+	}
+
+	for _, test := range tests {
+		org := strings.TrimPrefix(test.org, "Organization: ")
+		distroType, ok := spdxOrganizationToDistroType(org)
+		if ok != test.ok {
+			t.Errorf("spdxOrganizationToDistroType(%q) ok = %v, want %v", org, ok, test.ok)
+			continue
+		}
+		if ok && string(distroType) != test.expected {
+			t.Errorf("spdxOrganizationToDistroType(%q) = %q, want %q", org, distroType, test.expected)
+		}
+	}
+}
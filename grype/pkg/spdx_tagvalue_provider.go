@@ -0,0 +1,138 @@
+package pkg
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/anchore/grype/grype/pkg/format"
+)
+
+// init registers the SPDX tag-value decoder under the 2.2 and 2.3 schema series, the versions this decoder
+// understands.
+func init() {
+	format.RegisterDecoder("spdx-tag-value", []string{"2.2", "2.3"}, decodeSPDXTagValueDoc)
+}
+
+// decodeSPDXTagValueDoc decodes an SPDX tag-value document into a []spdxPackage.
+func decodeSPDXTagValueDoc(r io.Reader) (interface{}, error) {
+	pkgs, err := parseSPDXTagValue(r)
+	if err != nil {
+		return nil, err
+	}
+	return pkgs, nil
+}
+
+// spdxTagValueProvider extracts the necessary package and package context from an SPDX tag-value document
+// (the "SPDXVersion: ..." key-colon-value format, as opposed to the JSON serialization).
+func spdxTagValueProvider(config providerConfig) ([]Package, Context, error) {
+	reader, err := getSyftJSON(config) // tag-value SBOMs arrive through the same SBOM input plumbing as syft JSON
+	if err != nil {
+		return nil, Context{}, err
+	}
+	defer closeReader(reader)
+
+	id, version, buffered, err := format.Detect(reader)
+	if err != nil || id != "spdx-tag-value" {
+		return nil, Context{}, errDoesNotProvide
+	}
+
+	decoded, err := format.Decode(id, version, buffered)
+	if err != nil {
+		return nil, Context{}, fmt.Errorf("detected SPDX tag-value schema version %q but cannot read it: %w", version, err)
+	}
+
+	pkgs, ok := decoded.([]spdxPackage)
+	if !ok {
+		return nil, Context{}, fmt.Errorf("spdx-tag-value decoder returned unexpected type %T", decoded)
+	}
+
+	return spdxPackagesToCatalog(pkgs)
+}
+
+// parseSPDXTagValue performs a line-oriented parse of the tag-value format, accumulating one spdxPackage per
+// "PackageName:" tag encountered. Only the tags relevant to building a Package are retained.
+func parseSPDXTagValue(reader io.Reader) ([]spdxPackage, error) {
+	var packages []spdxPackage
+	var current *spdxPackage
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		tag, value, ok := splitSPDXTag(line)
+		if !ok {
+			continue
+		}
+
+		switch tag {
+		case "PackageName":
+			if current != nil {
+				packages = append(packages, *current)
+			}
+			current = &spdxPackage{Name: value}
+		case "PackageVersion":
+			if current != nil {
+				current.VersionInfo = value
+			}
+		case "PackageSupplier":
+			if current != nil {
+				current.Supplier = value
+			}
+		case "PackageOriginator":
+			if current != nil {
+				current.Originator = value
+			}
+		case "PackageLicenseConcluded":
+			if current != nil {
+				current.LicenseConcluded = value
+			}
+		case "PackageLicenseDeclared":
+			if current != nil {
+				current.LicenseDeclared = value
+			}
+		case "ExternalRef":
+			if current != nil {
+				if ref, ok := parseSPDXTagValueExternalRef(value); ok {
+					current.ExternalRefs = append(current.ExternalRefs, ref)
+				}
+			}
+		}
+	}
+
+	if current != nil {
+		packages = append(packages, *current)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return packages, nil
+}
+
+// splitSPDXTag splits a "Tag: value" line into its tag and value parts.
+func splitSPDXTag(line string) (string, string, bool) {
+	idx := strings.Index(line, ":")
+	if idx < 0 {
+		return "", "", false
+	}
+	return strings.TrimSpace(line[:idx]), strings.TrimSpace(line[idx+1:]), true
+}
+
+// parseSPDXTagValueExternalRef parses an "ExternalRef: CATEGORY TYPE LOCATOR" value into an spdxExternalRef.
+func parseSPDXTagValueExternalRef(value string) (spdxExternalRef, bool) {
+	fields := strings.Fields(value)
+	if len(fields) != 3 {
+		return spdxExternalRef{}, false
+	}
+	return spdxExternalRef{
+		ReferenceCategory: fields[0],
+		ReferenceType:     fields[1],
+		ReferenceLocator:  fields[2],
+	}, true
+}
@@ -0,0 +1,83 @@
+package pkg
+
+import (
+	"strings"
+	"testing"
+)
+
+const spdxTagValueFixture = `SPDXVersion: SPDX-2.2
+DataLicense: CC0-1.0
+DocumentName: example
+
+PackageName: curl
+PackageVersion: 7.68.0-1ubuntu2
+PackageLicenseConcluded: NOASSERTION
+PackageLicenseDeclared: MIT
+ExternalRef: PACKAGE-MANAGER purl pkg:deb/curl@7.68.0-1ubuntu2
+ExternalRef: SECURITY cpe23Type cpe:2.3:a:curl:curl:7.68.0:*:*:*:*:*:*:*
+
+PackageName: ubuntu
+PackageVersion: 20.04
+PackageSupplier: Organization: Canonical, Inc.
+ExternalRef: PACKAGE-MANAGER purl pkg:deb/ubuntu@20.04
+`
+
+func TestParseSPDXTagValue(t *testing.T) {
+	packages, err := parseSPDXTagValue(strings.NewReader(spdxTagValueFixture))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(packages) != 2 {
+		t.Fatalf("expected 2 packages, got %d", len(packages))
+	}
+
+	curl := packages[0]
+	if curl.Name != "curl" || curl.VersionInfo != "7.68.0-1ubuntu2" {
+		t.Errorf("unexpected curl package: %+v", curl)
+	}
+	if len(curl.ExternalRefs) != 2 {
+		t.Fatalf("expected 2 external refs, got %d", len(curl.ExternalRefs))
+	}
+
+	ubuntu := packages[1]
+	if ubuntu.Supplier != "Organization: Canonical, Inc." {
+		t.Errorf("unexpected supplier: %q", ubuntu.Supplier)
+	}
+
+	catalog, ctx, err := spdxPackagesToCatalog(packages)
+	if err != nil {
+		t.Fatalf("unexpected error converting to catalog: %v", err)
+	}
+	if len(catalog) != 2 {
+		t.Fatalf("expected 2 packages in catalog, got %d", len(catalog))
+	}
+	if ctx.Distro == nil || string(ctx.Distro.Type) != "ubuntu" {
+		t.Errorf("expected a ubuntu distro to be synthesized, got %+v", ctx.Distro)
+	}
+}
+
+func TestSplitSPDXTag(t *testing.T) {
+	tag, value, ok := splitSPDXTag("PackageName: curl")
+	if !ok || tag != "PackageName" || value != "curl" {
+		t.Errorf("unexpected split result: tag=%q value=%q ok=%v", tag, value, ok)
+	}
+
+	if _, _, ok := splitSPDXTag("not a tag line"); ok {
+		t.Error("expected a line with no colon to fail to split")
+	}
+}
+
+func TestParseSPDXTagValueExternalRef(t *testing.T) {
+	ref, ok := parseSPDXTagValueExternalRef("PACKAGE-MANAGER purl pkg:deb/curl@7.68.0-1ubuntu2")
+	if !ok {
+		t.Fatal("expected external ref to parse")
+	}
+	if ref.ReferenceCategory != "PACKAGE-MANAGER" || ref.ReferenceType != "purl" || ref.ReferenceLocator != "pkg:deb/curl@7.68.0-1ubuntu2" {
+		t.Errorf("unexpected parsed ref: %+v", ref)
+	}
+
+	if _, ok := parseSPDXTagValueExternalRef("PACKAGE-MANAGER purl"); ok {
+		t.Error("expected a malformed external ref to fail to parse")
+	}
+}
@@ -9,7 +9,7 @@ import (
 
 	"github.com/mitchellh/go-homedir"
 
-	"github.com/anchore/grype/grype/cpe"
+	"github.com/anchore/grype/grype/pkg/format"
 	"github.com/anchore/syft/syft/distro"
 	"github.com/anchore/syft/syft/pkg"
 	syftJson "github.com/anchore/syft/syft/presenter/json"
@@ -17,14 +17,15 @@ import (
 )
 
 // syftJSONProvider extracts the necessary package and package context from syft JSON output. Note that this process carves out
-// only the necessary data needed and does not require unmarshalling the entire syft JSON data shape so this function is somewhat
-// resilient to multiple syft JSON schemas (to a degree).
-// TODO: add version detection and multi-parser support (when needed in the future)
+// only the necessary data needed and does not require unmarshalling the entire syft JSON data shape. The document's schema
+// version is detected and dispatched to the matching registered decoder in the format package, so older syft JSON schemas
+// keep working even as the shape evolves.
 func syftJSONProvider(config providerConfig) ([]Package, Context, error) {
 	reader, err := getSyftJSON(config)
 	if err != nil {
 		return nil, Context{}, err
 	}
+	defer closeReader(reader)
 
 	return parseSyftJSON(reader)
 }
@@ -74,13 +75,6 @@ func explicitlySpecifyingSBOM(userInput string) bool {
 	return strings.HasPrefix(userInput, "sbom:")
 }
 
-// partialSyftDoc is the final package shape for a select elements from a syft JSON document.
-type partialSyftDoc struct {
-	Source    syftJson.Source       `json:"source"`
-	Artifacts []partialSyftPackage  `json:"artifacts"`
-	Distro    syftJson.Distribution `json:"distro"`
-}
-
 // partialSyftPackage is the final package shape for a select elements from a syft JSON package.
 type partialSyftPackage struct {
 	packageBasicMetadata
@@ -89,6 +83,7 @@ type partialSyftPackage struct {
 
 // packageBasicMetadata contains non-ambiguous values (type-wise) from pkg.Package.
 type packageBasicMetadata struct {
+	ID        string            `json:"id"`
 	Name      string            `json:"name"`
 	Version   string            `json:"version"`
 	Type      pkg.Type          `json:"type"`
@@ -99,6 +94,35 @@ type packageBasicMetadata struct {
 	PURL      string            `json:"purl"`
 }
 
+// FileMetadata is a single file's path and content digests as recorded by syft, used to disambiguate a
+// repackaged artifact from its upstream by digest (e.g. a Java matcher comparing a known-vulnerable file hash).
+type FileMetadata struct {
+	Path    string   `json:"path"`
+	Digests []Digest `json:"digests"`
+}
+
+// Digest is a single named-algorithm content digest (e.g. "sha256").
+type Digest struct {
+	Algorithm string `json:"algorithm"`
+	Value     string `json:"value"`
+}
+
+// partialSyftFile is a top-level normalized file record syft emits alongside "artifacts", referenced by id from
+// "artifactRelationships".
+type partialSyftFile struct {
+	ID       string          `json:"id"`
+	Location source.Location `json:"location"`
+	Digests  []Digest        `json:"digests"`
+}
+
+// partialSyftRelationship is a syft "artifactRelationships" entry; relationships of type "contains" link a
+// package (parent) to the files it owns (child).
+type partialSyftRelationship struct {
+	Parent string `json:"parent"`
+	Child  string `json:"child"`
+	Type   string `json:"type"`
+}
+
 // packageCustomMetadata contains ambiguous values (type-wise) from pkg.Package.
 type packageCustomMetadata struct {
 	MetadataType pkg.MetadataType `json:"metadataType"`
@@ -113,9 +137,10 @@ type packageMetadataUnpacker struct {
 
 // partialSyftJavaMetadata encapsulates all Java ecosystem metadata for a package as well as an (optional) parent relationship.
 type partialSyftJavaMetadata struct {
-	VirtualPath   string                    `json:"virtualPath"`
-	Manifest      *partialSyftJavaManifest  `mapstructure:"Manifest" json:"manifest,omitempty"`
-	PomProperties *partialSyftPomProperties `mapstructure:"PomProperties" json:"pomProperties,omitempty"`
+	VirtualPath    string                    `json:"virtualPath"`
+	Manifest       *partialSyftJavaManifest  `mapstructure:"Manifest" json:"manifest,omitempty"`
+	PomProperties  *partialSyftPomProperties `mapstructure:"PomProperties" json:"pomProperties,omitempty"`
+	ArchiveDigests []Digest                  `json:"archiveDigests,omitempty"`
 }
 
 // partialSyftPomProperties represents the fields of interest extracted from a Java archive's pom.xml file.
@@ -181,58 +206,180 @@ func (p *partialSyftPackage) UnmarshalJSON(b []byte) error {
 		}
 
 		p.Metadata = JavaMetadata{
-			PomArtifactID: artifact,
-			PomGroupID:    group,
-			ManifestName:  name,
+			PomArtifactID:  artifact,
+			PomGroupID:     group,
+			ManifestName:   name,
+			ArchiveDigests: partialPayload.ArchiveDigests,
 		}
 	}
 
 	return nil
 }
 
-// parseSyftJSON attempts to loosely parse the available JSON for only the fields needed, not the exact syft JSON shape.
-// This allows for some resiliency as the syft document shape changes over time (but not fool-proof).
-func parseSyftJSON(reader io.Reader) ([]Package, Context, error) {
-	var doc partialSyftDoc
-	decoder := json.NewDecoder(reader)
-	if err := decoder.Decode(&doc); err != nil {
-		return nil, Context{}, errDoesNotProvide
+// init registers the syft JSON decoder under every schema version series grype still knows how to read, so that
+// older Syft SBOMs continue to provide packages even after a future schema bump changes the document shape.
+func init() {
+	format.RegisterDecoder("syft-json", []string{"1", "2", "3"}, decodeSyftJSONDoc)
+}
+
+// syftJSONResult is the fully-assembled output of streaming a syft JSON document: final Package values plus the
+// document-level source/distro blocks, built up directly as the document is read rather than first materializing
+// an intermediate whole-document struct.
+type syftJSONResult struct {
+	Packages []Package
+	Source   syftJson.Source
+	Distro   syftJson.Distribution
+}
+
+// decodeSyftJSONDoc token-streams a syft JSON document rather than decoding it in one shot, so that SBOMs with
+// tens of thousands of artifacts don't require holding the entire document in memory at once. The top-level
+// object's keys are dispatched as they're encountered; "artifacts" is decoded one package at a time directly into
+// the final Package, with the "files"/"artifactRelationships" digest data (which may appear before or after
+// "artifacts") patched in once the whole document has been read.
+func decodeSyftJSONDoc(r io.Reader) (interface{}, error) {
+	decoder := json.NewDecoder(r)
+
+	if _, err := decoder.Token(); err != nil { // consume the opening '{'
+		return nil, err
 	}
 
-	var packages = make([]Package, len(doc.Artifacts))
-	for i, a := range doc.Artifacts {
-		cpes, err := cpe.NewSlice(a.CPEs...)
+	result := &syftJSONResult{}
+	var packageIDs []string
+	var files []partialSyftFile
+	var relationships []partialSyftRelationship
+
+	for decoder.More() {
+		keyToken, err := decoder.Token()
 		if err != nil {
-			return nil, Context{}, err
+			return nil, err
 		}
 
-		packages[i] = Package{
-			id:        ID(i),
-			Name:      a.Name,
-			Version:   a.Version,
-			Locations: a.Locations,
-			Language:  a.Language,
-			Licenses:  a.Licenses,
-			Type:      a.Type,
-			CPEs:      cpes,
-			PURL:      a.PURL,
-			Metadata:  a.Metadata,
+		key, _ := keyToken.(string)
+		switch key {
+		case "source":
+			if err := decoder.Decode(&result.Source); err != nil {
+				return nil, err
+			}
+		case "distro":
+			if err := decoder.Decode(&result.Distro); err != nil {
+				return nil, err
+			}
+		case "files":
+			if err := decoder.Decode(&files); err != nil {
+				return nil, err
+			}
+		case "artifactRelationships":
+			if err := decoder.Decode(&relationships); err != nil {
+				return nil, err
+			}
+		case "artifacts":
+			if _, err := decoder.Token(); err != nil { // consume the opening '['
+				return nil, err
+			}
+
+			for decoder.More() {
+				var a partialSyftPackage
+				if err := decoder.Decode(&a); err != nil {
+					return nil, err
+				}
+
+				result.Packages = append(result.Packages, Package{
+					id:        ID(len(result.Packages)),
+					Name:      a.Name,
+					Version:   a.Version,
+					Locations: a.Locations,
+					Language:  a.Language,
+					Licenses:  a.Licenses,
+					Type:      a.Type,
+					CPEs:      validCPEs(a.CPEs),
+					PURL:      a.PURL,
+					Metadata:  a.Metadata,
+				})
+				packageIDs = append(packageIDs, a.ID)
+			}
+
+			if _, err := decoder.Token(); err != nil { // consume the closing ']'
+				return nil, err
+			}
+		default:
+			var ignored interface{}
+			if err := decoder.Decode(&ignored); err != nil {
+				return nil, err
+			}
 		}
 	}
 
+	filesByPackageID := syftFilesByPackageID(files, relationships)
+	for i, id := range packageIDs {
+		result.Packages[i].Files = filesByPackageID[id]
+	}
+
+	return result, nil
+}
+
+// parseSyftJSON detects the schema version of the given syft JSON document and decodes it with the matching
+// registered decoder, so that older documents a future schema bump would otherwise break on still provide packages.
+func parseSyftJSON(reader io.Reader) ([]Package, Context, error) {
+	id, version, buffered, err := format.Detect(reader)
+	if err != nil {
+		return nil, Context{}, errDoesNotProvide
+	}
+
+	if id != "syft-json" {
+		return nil, Context{}, errDoesNotProvide
+	}
+
+	decoded, err := format.Decode(id, version, buffered)
+	if err != nil {
+		return nil, Context{}, fmt.Errorf("detected syft JSON schema version %q but cannot read it: %w", version, err)
+	}
+
+	result, ok := decoded.(*syftJSONResult)
+	if !ok {
+		return nil, Context{}, fmt.Errorf("syft-json decoder returned unexpected type %T", decoded)
+	}
+
 	var theDistro *distro.Distro
-	if doc.Distro.Name != "" {
-		d, err := distro.NewDistro(distro.Type(doc.Distro.Name), doc.Distro.Version, doc.Distro.IDLike)
+	if result.Distro.Name != "" {
+		d, err := distro.NewDistro(distro.Type(result.Distro.Name), result.Distro.Version, result.Distro.IDLike)
 		if err != nil {
 			return nil, Context{}, err
 		}
 		theDistro = &d
 	}
 
-	srcMetadata := doc.Source.ToSourceMetadata()
+	srcMetadata := result.Source.ToSourceMetadata()
 
-	return packages, Context{
+	return result.Packages, Context{
 		Source: &srcMetadata,
 		Distro: theDistro,
 	}, nil
 }
+
+// syftFilesByPackageID indexes the document's top-level files by the package that "contains" them, so that
+// matchers can disambiguate a repackaged artifact from its upstream by digest.
+func syftFilesByPackageID(files []partialSyftFile, relationships []partialSyftRelationship) map[string][]FileMetadata {
+	filesByID := make(map[string]partialSyftFile, len(files))
+	for _, f := range files {
+		filesByID[f.ID] = f
+	}
+
+	result := make(map[string][]FileMetadata)
+	for _, rel := range relationships {
+		if rel.Type != "contains" {
+			continue
+		}
+
+		f, exists := filesByID[rel.Child]
+		if !exists {
+			continue
+		}
+
+		result[rel.Parent] = append(result[rel.Parent], FileMetadata{
+			Path:    f.Location.RealPath,
+			Digests: f.Digests,
+		})
+	}
+
+	return result
+}
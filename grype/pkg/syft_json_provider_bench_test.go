@@ -0,0 +1,36 @@
+package pkg
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+// buildLargeSyftJSONFixture synthesizes a syft JSON document with the given number of rpm artifacts, used to
+// benchmark parseSyftJSON against SBOMs on the scale of a large container image.
+func buildLargeSyftJSONFixture(numArtifacts int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`{"source":{"type":"image","target":{}},"distro":{},"artifacts":[`)
+	for i := 0; i < numArtifacts; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, `{"id":"pkg-%d","name":"package-%d","version":"1.0.%d","type":"rpm","locations":[],"licenses":["MIT"],"language":"","cpes":[],"purl":"pkg:rpm/package-%d@1.0.%d","metadataType":"","metadata":null}`, i, i, i, i, i)
+	}
+	buf.WriteString(`],"files":[],"artifactRelationships":[]}`)
+	return buf.Bytes()
+}
+
+// BenchmarkParseSyftJSONLarge exercises parseSyftJSON against a ≥50k-package document, the scale at which
+// materializing the whole document before conversion noticeably increases peak memory.
+func BenchmarkParseSyftJSONLarge(b *testing.B) {
+	fixture := buildLargeSyftJSONFixture(50000)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := parseSyftJSON(bytes.NewReader(fixture)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package pkg
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/anchore/syft/syft/source"
+)
+
+const syftJSONFixture = `{
+  "source": {"type": "image", "target": {}},
+  "distro": {"name": "ubuntu", "version": "20.04", "idLike": ""},
+  "artifacts": [
+    {
+      "id": "pkg-1",
+      "name": "curl",
+      "version": "7.68.0-1ubuntu2",
+      "type": "deb",
+      "locations": [],
+      "licenses": ["MIT"],
+      "language": "",
+      "cpes": ["cpe:2.3:a:curl:curl:7.68.0:*:*:*:*:*:*:*"],
+      "purl": "pkg:deb/curl@7.68.0-1ubuntu2",
+      "metadataType": "",
+      "metadata": null
+    },
+    {
+      "id": "pkg-2",
+      "name": "some-lib",
+      "version": "1.2.3",
+      "type": "java-archive",
+      "locations": [],
+      "licenses": [],
+      "language": "java",
+      "cpes": [],
+      "purl": "pkg:maven/com.example/some-lib@1.2.3",
+      "metadataType": "JavaMetadata",
+      "metadata": {
+        "virtualPath": "",
+        "pomProperties": {"groupId": "com.example", "artifactId": "some-lib"},
+        "archiveDigests": [{"algorithm": "sha256", "value": "deadbeef"}]
+      }
+    }
+  ],
+  "files": [
+    {"id": "file-1", "location": {}, "digests": [{"algorithm": "sha256", "value": "abc123"}]}
+  ],
+  "artifactRelationships": [
+    {"parent": "pkg-1", "child": "file-1", "type": "contains"},
+    {"parent": "pkg-1", "child": "nonexistent-file", "type": "contains"},
+    {"parent": "pkg-2", "child": "file-1", "type": "describes"}
+  ]
+}`
+
+func TestParseSyftJSON(t *testing.T) {
+	packages, ctx, err := parseSyftJSON(strings.NewReader(syftJSONFixture))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(packages) != 2 {
+		t.Fatalf("expected 2 packages, got %d", len(packages))
+	}
+
+	curl := packages[0]
+	if curl.Name != "curl" || len(curl.CPEs) != 1 {
+		t.Errorf("unexpected curl package: %+v", curl)
+	}
+	if len(curl.Files) != 1 || curl.Files[0].Digests[0].Value != "abc123" {
+		t.Errorf("expected curl to have the 'contains' file joined in, got %+v", curl.Files)
+	}
+
+	lib := packages[1]
+	meta, ok := lib.Metadata.(JavaMetadata)
+	if !ok {
+		t.Fatalf("expected JavaMetadata, got %T", lib.Metadata)
+	}
+	if meta.PomArtifactID != "some-lib" || meta.PomGroupID != "com.example" {
+		t.Errorf("unexpected java metadata: %+v", meta)
+	}
+	if len(meta.ArchiveDigests) != 1 || meta.ArchiveDigests[0].Value != "deadbeef" {
+		t.Errorf("expected archive digests to be populated, got %+v", meta.ArchiveDigests)
+	}
+	if len(lib.Files) != 0 {
+		t.Errorf("expected some-lib to have no joined files (relationship type was not 'contains'), got %+v", lib.Files)
+	}
+
+	if ctx.Distro == nil || string(ctx.Distro.Type) != "ubuntu" {
+		t.Errorf("expected a ubuntu distro, got %+v", ctx.Distro)
+	}
+}
+
+const syftJSONBadCPEFixture = `{
+  "source": {"type": "image", "target": {}},
+  "distro": {},
+  "artifacts": [
+    {
+      "id": "pkg-1",
+      "name": "curl",
+      "version": "7.68.0-1ubuntu2",
+      "type": "deb",
+      "locations": [],
+      "licenses": [],
+      "language": "",
+      "cpes": ["not-a-valid-cpe"],
+      "purl": "pkg:deb/curl@7.68.0-1ubuntu2",
+      "metadataType": "",
+      "metadata": null
+    },
+    {
+      "id": "pkg-2",
+      "name": "openssl",
+      "version": "1.1.1f",
+      "type": "deb",
+      "locations": [],
+      "licenses": [],
+      "language": "",
+      "cpes": ["cpe:2.3:a:openssl:openssl:1.1.1f:*:*:*:*:*:*:*"],
+      "purl": "pkg:deb/openssl@1.1.1f",
+      "metadataType": "",
+      "metadata": null
+    }
+  ],
+  "files": [],
+  "artifactRelationships": []
+}`
+
+func TestParseSyftJSONSkipsBadCPE(t *testing.T) {
+	packages, _, err := parseSyftJSON(strings.NewReader(syftJSONBadCPEFixture))
+	if err != nil {
+		t.Fatalf("a malformed CPE on one artifact should not abort parsing of the rest of the document: %v", err)
+	}
+	if len(packages) != 2 {
+		t.Fatalf("expected both packages to still be present, got %d", len(packages))
+	}
+
+	if len(packages[0].CPEs) != 0 {
+		t.Errorf("expected the unparsable CPE to be dropped, got %v", packages[0].CPEs)
+	}
+	if len(packages[1].CPEs) != 1 {
+		t.Errorf("expected the valid CPE on the second package to still parse, got %v", packages[1].CPEs)
+	}
+}
+
+func TestSyftFilesByPackageID(t *testing.T) {
+	files := []partialSyftFile{
+		{ID: "file-1", Location: source.Location{RealPath: "/usr/bin/curl"}, Digests: []Digest{{Algorithm: "sha256", Value: "abc123"}}},
+		{ID: "file-2", Location: source.Location{RealPath: "/usr/lib/libcurl.so"}, Digests: []Digest{{Algorithm: "sha256", Value: "def456"}}},
+	}
+	relationships := []partialSyftRelationship{
+		{Parent: "pkg-1", Child: "file-1", Type: "contains"},
+		{Parent: "pkg-1", Child: "file-2", Type: "contains"},
+		{Parent: "pkg-1", Child: "missing-file", Type: "contains"},
+		{Parent: "pkg-2", Child: "file-1", Type: "describes"},
+	}
+
+	byID := syftFilesByPackageID(files, relationships)
+
+	pkg1Files := byID["pkg-1"]
+	if len(pkg1Files) != 2 {
+		t.Fatalf("expected 2 files joined to pkg-1, got %d", len(pkg1Files))
+	}
+	if pkg1Files[0].Path != "/usr/bin/curl" || pkg1Files[1].Path != "/usr/lib/libcurl.so" {
+		t.Errorf("unexpected file paths: %+v", pkg1Files)
+	}
+
+	if _, ok := byID["pkg-2"]; ok {
+		t.Error("expected no files joined to pkg-2, since its only relationship isn't 'contains'")
+	}
+}